@@ -0,0 +1,362 @@
+package prioqueue
+
+import "math/bits"
+
+// NewBounded creates a capacity-limited priority queue backed by a
+// min-max heap, which supports O(log n) access to both ends so the
+// opposite end from Peek/Dequeue can be evicted cheaply whenever Enqueue
+// would push the queue past capacity. This is the common shape for
+// top-K stream processing and leaderboard truncation.
+//
+// If evictLowest is true, Enqueue evicts the lowest-priority item and
+// Peek/Dequeue expose the highest-priority item; if false, it's the
+// other way around. Use EnqueueEvicting to observe what was evicted.
+//
+// The min-max heap orders items via pq.mmLess, so a comparator supplied
+// through NewWithComparator and FIFO tie-breaking from NewStable are
+// honored the same as on an unbounded queue.
+func NewBounded[T any](capacity int, evictLowest bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{
+		items:       make([]*Item[T], 0),
+		bounded:     true,
+		isMaxHeap:   evictLowest,
+		capacity:    capacity,
+		evictLowest: evictLowest,
+	}
+}
+
+// SetCapacity bounds pq to at most n items. If pq isn't already bounded,
+// it is converted to a min-max heap in place (a pairing-heap-backed queue
+// included), evicting from the end opposite Peek/Dequeue: the high end
+// for a min-ordered queue, the low end for a max-ordered one. If it
+// already holds more than n items, the excess is evicted from the
+// configured eviction end until it fits. n <= 0 means unlimited.
+//
+// Conversion preserves pq's existing ordering: a comparator set via
+// NewWithComparator and FIFO tie-breaking set via NewStable keep working
+// exactly as before, since the min-max heap orders items via pq.mmLess
+// rather than comparing Priority directly.
+func (pq *PriorityQueue[T]) SetCapacity(n int) {
+	pq.mutex.Lock()
+	defer pq.mutex.Unlock()
+
+	if !pq.bounded {
+		if pq.pairing {
+			pq.items = pq.extractAll()
+			pq.pairing = false
+			for i, item := range pq.items {
+				item.Index = i
+			}
+		}
+		pq.bounded = true
+		pq.evictLowest = pq.isMaxHeap
+		pq.mmBuildHeap()
+	}
+	pq.capacity = n
+
+	for pq.capacity > 0 && len(pq.items) > pq.capacity {
+		if pq.evictLowest {
+			pq.mmRemoveAt(0)
+		} else {
+			pq.mmRemoveAt(pq.mmMaxIndex())
+		}
+	}
+}
+
+// PeekMin returns the lowest-priority item in a bounded queue without
+// removing it.
+func (pq *PriorityQueue[T]) PeekMin() (T, bool) {
+	pq.mutex.RLock()
+	defer pq.mutex.RUnlock()
+
+	var zero T
+	if !pq.bounded || len(pq.items) == 0 {
+		return zero, false
+	}
+	return pq.items[0].Value, true
+}
+
+// PeekMax returns the highest-priority item in a bounded queue without
+// removing it.
+func (pq *PriorityQueue[T]) PeekMax() (T, bool) {
+	pq.mutex.RLock()
+	defer pq.mutex.RUnlock()
+
+	var zero T
+	if !pq.bounded || len(pq.items) == 0 {
+		return zero, false
+	}
+	return pq.items[pq.mmMaxIndex()].Value, true
+}
+
+// PopMin removes and returns the lowest-priority item in a bounded queue.
+func (pq *PriorityQueue[T]) PopMin() (T, bool) {
+	pq.mutex.Lock()
+	defer pq.mutex.Unlock()
+
+	var zero T
+	if !pq.bounded {
+		return zero, false
+	}
+	item := pq.mmPopMin()
+	if item == nil {
+		return zero, false
+	}
+	return item.Value, true
+}
+
+// PopMax removes and returns the highest-priority item in a bounded queue.
+func (pq *PriorityQueue[T]) PopMax() (T, bool) {
+	pq.mutex.Lock()
+	defer pq.mutex.Unlock()
+
+	var zero T
+	if !pq.bounded {
+		return zero, false
+	}
+	item := pq.mmPopMax()
+	if item == nil {
+		return zero, false
+	}
+	return item.Value, true
+}
+
+// --- min-max heap internals, operating directly on pq.items ---
+//
+// Levels alternate min/max starting from the root (level 0, a min
+// level): a node on a min level is <= all its descendants, and a node
+// on a max level is >= all its descendants. This gives O(1) peek and
+// O(log n) pop at both ends, unlike a plain binary heap which only
+// offers O(1) access to one end.
+
+func mmParent(i int) int {
+	return (i - 1) / 2
+}
+
+func mmGrandparent(i int) int {
+	return mmParent(mmParent(i))
+}
+
+func mmLevelIsMin(i int) bool {
+	level := bits.Len(uint(i+1)) - 1
+	return level%2 == 0
+}
+
+func (pq *PriorityQueue[T]) mmSwap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].Index = i
+	pq.items[j].Index = j
+}
+
+func (pq *PriorityQueue[T]) mmBuildHeap() {
+	for i := len(pq.items)/2 - 1; i >= 0; i-- {
+		pq.mmPushDown(i)
+	}
+}
+
+func (pq *PriorityQueue[T]) mmInsert(item *Item[T]) {
+	item.Index = len(pq.items)
+	pq.items = append(pq.items, item)
+	pq.mmPushUp(item.Index)
+}
+
+// mmMaxIndex returns the index holding the item pq.mmLess ranks last
+// (only valid while the heap invariant holds): the root if it's the only
+// element, otherwise whichever of its two children (index 1 or 2) ranks
+// last.
+func (pq *PriorityQueue[T]) mmMaxIndex() int {
+	n := len(pq.items)
+	if n == 0 {
+		return -1
+	}
+	if n == 1 {
+		return 0
+	}
+	idx := 1
+	if n > 2 && pq.mmLess(pq.items[1], pq.items[2]) {
+		idx = 2
+	}
+	return idx
+}
+
+func (pq *PriorityQueue[T]) mmPopMin() *Item[T] {
+	if len(pq.items) == 0 {
+		return nil
+	}
+	return pq.mmRemoveAt(0)
+}
+
+func (pq *PriorityQueue[T]) mmPopMax() *Item[T] {
+	idx := pq.mmMaxIndex()
+	if idx < 0 {
+		return nil
+	}
+	return pq.mmRemoveAt(idx)
+}
+
+// mmRemoveAt removes the item at index i, moving the last item into its
+// place and restoring the heap invariant.
+func (pq *PriorityQueue[T]) mmRemoveAt(i int) *Item[T] {
+	last := len(pq.items) - 1
+	removed := pq.items[i]
+	removed.Index = -1
+
+	if i != last {
+		pq.items[i] = pq.items[last]
+		pq.items[i].Index = i
+	}
+	pq.items[last] = nil
+	pq.items = pq.items[:last]
+
+	if i < len(pq.items) {
+		pq.mmPushUp(pq.mmPushDown(i))
+	}
+	return removed
+}
+
+func (pq *PriorityQueue[T]) mmPushUp(i int) {
+	if i == 0 {
+		return
+	}
+	p := mmParent(i)
+	if mmLevelIsMin(i) {
+		if pq.mmLess(pq.items[p], pq.items[i]) {
+			pq.mmSwap(i, p)
+			pq.mmPushUpMax(p)
+		} else {
+			pq.mmPushUpMin(i)
+		}
+	} else {
+		if pq.mmLess(pq.items[i], pq.items[p]) {
+			pq.mmSwap(i, p)
+			pq.mmPushUpMin(p)
+		} else {
+			pq.mmPushUpMax(i)
+		}
+	}
+}
+
+func (pq *PriorityQueue[T]) mmPushUpMin(i int) {
+	for i >= 3 {
+		gp := mmGrandparent(i)
+		if !pq.mmLess(pq.items[i], pq.items[gp]) {
+			return
+		}
+		pq.mmSwap(i, gp)
+		i = gp
+	}
+}
+
+func (pq *PriorityQueue[T]) mmPushUpMax(i int) {
+	for i >= 3 {
+		gp := mmGrandparent(i)
+		if !pq.mmLess(pq.items[gp], pq.items[i]) {
+			return
+		}
+		pq.mmSwap(i, gp)
+		i = gp
+	}
+}
+
+// mmExtremeDescendant finds the child or grandchild of i that prefer
+// ranks highest (e.g. smallest priority for a min push-down).
+func (pq *PriorityQueue[T]) mmExtremeDescendant(i int, prefer func(a, b *Item[T]) bool) (idx int, isGrandchild bool, ok bool) {
+	n := len(pq.items)
+	best := -1
+
+	check := func(j int, isG bool) {
+		if j >= n {
+			return
+		}
+		if best == -1 || prefer(pq.items[j], pq.items[best]) {
+			best = j
+			isGrandchild = isG
+		}
+	}
+	check(2*i+1, false)
+	check(2*i+2, false)
+	check(4*i+3, true)
+	check(4*i+4, true)
+	check(4*i+5, true)
+	check(4*i+6, true)
+
+	if best == -1 {
+		return 0, false, false
+	}
+	return best, isGrandchild, true
+}
+
+// mmPushDown restores the heap invariant below i and returns the index
+// the element originally at i ends up at.
+func (pq *PriorityQueue[T]) mmPushDown(i int) int {
+	if mmLevelIsMin(i) {
+		return pq.mmPushDownMin(i)
+	}
+	return pq.mmPushDownMax(i)
+}
+
+// mmPushDownMin is mmPushDownMax's mirror for a min-level i: see it for
+// why settledAt exists.
+func (pq *PriorityQueue[T]) mmPushDownMin(i int) int {
+	settledAt := -1
+	for {
+		m, isGrandchild, ok := pq.mmExtremeDescendant(i, pq.mmLess)
+		if !ok || !pq.mmLess(pq.items[m], pq.items[i]) {
+			if settledAt >= 0 {
+				return settledAt
+			}
+			return i
+		}
+		pq.mmSwap(m, i)
+		if !isGrandchild {
+			if settledAt >= 0 {
+				return settledAt
+			}
+			return m
+		}
+		if p := mmParent(m); pq.mmLess(pq.items[p], pq.items[m]) {
+			// The value that started this call just moved from m to p,
+			// where it settles for good: later iterations only ever look
+			// at m's descendants, never back up at p. From here on the
+			// loop is trickling a *different* value (the one displaced
+			// from p to m) further down, so the return index must be
+			// pinned to p now rather than wherever that continues to.
+			pq.mmSwap(m, p)
+			if settledAt < 0 {
+				settledAt = p
+			}
+		}
+		i = m
+	}
+}
+
+func (pq *PriorityQueue[T]) mmPushDownMax(i int) int {
+	settledAt := -1
+	for {
+		m, isGrandchild, ok := pq.mmExtremeDescendant(i, func(a, b *Item[T]) bool { return pq.mmLess(b, a) })
+		if !ok || !pq.mmLess(pq.items[i], pq.items[m]) {
+			if settledAt >= 0 {
+				return settledAt
+			}
+			return i
+		}
+		pq.mmSwap(m, i)
+		if !isGrandchild {
+			if settledAt >= 0 {
+				return settledAt
+			}
+			return m
+		}
+		if p := mmParent(m); pq.mmLess(pq.items[m], pq.items[p]) {
+			// See mmPushDownMin: the value that started this call has
+			// now settled permanently at p, so pin the return index
+			// there even though the loop keeps going to trickle the
+			// value displaced from p down through m's subtree.
+			pq.mmSwap(m, p)
+			if settledAt < 0 {
+				settledAt = p
+			}
+		}
+		i = m
+	}
+}
@@ -0,0 +1,92 @@
+package prioqueue
+
+import (
+	"context"
+	"sync"
+)
+
+// ensureCond lazily builds the condition variable used by
+// DequeueBlocking/Chan, guarded by pq.mutex. It's safe to call under
+// either the read or write lock.
+func (pq *PriorityQueue[T]) ensureCond() *sync.Cond {
+	pq.condOnce.Do(func() {
+		pq.cond = sync.NewCond(&pq.mutex)
+	})
+	return pq.cond
+}
+
+// DequeueBlocking removes and returns the highest priority item, waiting
+// for one to become available if the queue is empty. It returns ctx.Err()
+// if ctx is cancelled before an item arrives.
+func (pq *PriorityQueue[T]) DequeueBlocking(ctx context.Context) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	cond := pq.ensureCond()
+
+	// Cond.Wait only wakes on Broadcast/Signal, so a goroutine nudges it
+	// whenever ctx is done, letting the wait loop below notice ctx.Err().
+	watcherDone := make(chan struct{})
+	defer close(watcherDone)
+	go func() {
+		select {
+		case <-ctx.Done():
+			pq.mutex.Lock()
+			cond.Broadcast()
+			pq.mutex.Unlock()
+		case <-watcherDone:
+		}
+	}()
+
+	pq.mutex.Lock()
+	defer pq.mutex.Unlock()
+	for pq.Len() == 0 {
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+		cond.Wait()
+	}
+	value, _ := pq.dequeueLocked()
+	return value, nil
+}
+
+// Chan returns a channel that yields items in priority order as they
+// become available, backed by a goroutine that calls DequeueBlocking in
+// a loop. Call Close to shut it down; the channel is closed once the
+// goroutine has stopped. Calling Chan more than once returns the same
+// channel.
+func (pq *PriorityQueue[T]) Chan() <-chan T {
+	pq.chanOnce.Do(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		pq.chanCancel = cancel
+
+		out := make(chan T)
+		pq.chanOut = out
+
+		go func() {
+			defer close(out)
+			for {
+				value, err := pq.DequeueBlocking(ctx)
+				if err != nil {
+					return
+				}
+				select {
+				case out <- value:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	})
+	return pq.chanOut
+}
+
+// Close shuts down the goroutine started by Chan, if any, and closes its
+// channel. It is safe to call even if Chan was never called.
+func (pq *PriorityQueue[T]) Close() {
+	if pq.chanCancel != nil {
+		pq.chanCancel()
+	}
+}
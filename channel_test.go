@@ -0,0 +1,81 @@
+package prioqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDequeueBlockingWaitsForItem(t *testing.T) {
+	pq := New[string]()
+
+	type outcome struct {
+		value string
+		err   error
+	}
+	result := make(chan outcome, 1)
+	go func() {
+		value, err := pq.DequeueBlocking(context.Background())
+		result <- outcome{value, err}
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	pq.Enqueue("task", 1)
+
+	select {
+	case got := <-result:
+		if got.err != nil {
+			t.Errorf("Unexpected error: %v", got.err)
+		}
+		if got.value != "task" {
+			t.Errorf("Expected 'task', got %v", got.value)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("DequeueBlocking did not return after Enqueue")
+	}
+}
+
+func TestDequeueBlockingContextCancel(t *testing.T) {
+	pq := New[string]()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := pq.DequeueBlocking(ctx)
+		errCh <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if err == nil {
+			t.Error("Expected an error after context cancellation")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("DequeueBlocking did not return after context cancellation")
+	}
+}
+
+func TestChanDeliversInPriorityOrder(t *testing.T) {
+	pq := New[int]()
+	ch := pq.Chan()
+
+	pq.Enqueue(3, 3)
+	pq.Enqueue(1, 1)
+	pq.Enqueue(2, 2)
+
+	for _, want := range []int{1, 2, 3} {
+		select {
+		case value := <-ch:
+			if value != want {
+				t.Errorf("Expected %d, got %v", want, value)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for Chan value")
+		}
+	}
+
+	pq.Close()
+}
@@ -0,0 +1,127 @@
+package prioqueue
+
+import "container/heap"
+
+// Iterator walks a PriorityQueue's items in priority order without
+// draining the original queue. Because a heap's backing slice isn't
+// sorted, Iterator works by cloning the items into a scratch heap once
+// and popping from that on each Next call.
+type Iterator[T any] struct {
+	scratch *PriorityQueue[T]
+}
+
+// Iterator returns an Iterator over pq's current items in priority
+// order. The iterator reflects a snapshot taken at the time of the
+// call; later changes to pq are not visible through it.
+func (pq *PriorityQueue[T]) Iterator() *Iterator[T] {
+	pq.mutex.RLock()
+	defer pq.mutex.RUnlock()
+
+	return &Iterator[T]{scratch: pq.cloneForTraversal()}
+}
+
+// cloneForTraversal snapshots pq's items into a plain binary-heap-backed
+// queue sharing pq's ordering (comparator, orientation, stability), for
+// use by Iterator/Sorted/Range/Filter. Callers must hold at least
+// pq.mutex.RLock.
+func (pq *PriorityQueue[T]) cloneForTraversal() *PriorityQueue[T] {
+	items := pq.items
+	if pq.pairing {
+		items = pq.pairingCollect()
+	}
+
+	clone := &PriorityQueue[T]{
+		comparator: pq.comparator,
+		isMaxHeap:  pq.isMaxHeap,
+		stable:     pq.stable,
+		items:      make([]*Item[T], len(items)),
+	}
+	for i, item := range items {
+		clone.items[i] = &Item[T]{Value: item.Value, Priority: item.Priority, Index: i, seq: item.seq}
+	}
+	heap.Init(clone)
+	return clone
+}
+
+// Next returns the next item in priority order, or false once the
+// iterator is exhausted.
+func (it *Iterator[T]) Next() (Item[T], bool) {
+	if it.scratch.Len() == 0 {
+		return Item[T]{}, false
+	}
+	item := heap.Pop(it.scratch).(*Item[T])
+	return *item, true
+}
+
+// HasNext reports whether Next has any items left to return.
+func (it *Iterator[T]) HasNext() bool {
+	return it.scratch.Len() > 0
+}
+
+// Sorted returns a fully-sorted copy of pq's items in priority order, in
+// O(n log n).
+func (pq *PriorityQueue[T]) Sorted() []Item[T] {
+	it := pq.Iterator()
+
+	result := make([]Item[T], 0, it.scratch.Len())
+	for {
+		item, ok := it.Next()
+		if !ok {
+			return result
+		}
+		result = append(result, item)
+	}
+}
+
+// Range calls fn for each item in priority order, stopping early if fn
+// returns false.
+func (pq *PriorityQueue[T]) Range(fn func(Item[T]) bool) {
+	it := pq.Iterator()
+	for {
+		item, ok := it.Next()
+		if !ok {
+			return
+		}
+		if !fn(item) {
+			return
+		}
+	}
+}
+
+// Filter returns a new priority queue, sharing pq's comparator,
+// orientation, stability and (if pq is bounded, see NewBounded/
+// SetCapacity) capacity and eviction end, containing only the items for
+// which pred returns true. The new queue is built in a single pass
+// rather than repeated Enqueue, so it costs O(n) for an unbounded pq or
+// O(n) for a bounded one (heap.Init vs. mmBuildHeap).
+func (pq *PriorityQueue[T]) Filter(pred func(T) bool) *PriorityQueue[T] {
+	pq.mutex.RLock()
+	defer pq.mutex.RUnlock()
+
+	items := pq.items
+	if pq.pairing {
+		items = pq.pairingCollect()
+	}
+
+	result := &PriorityQueue[T]{
+		comparator:  pq.comparator,
+		isMaxHeap:   pq.isMaxHeap,
+		stable:      pq.stable,
+		bounded:     pq.bounded,
+		capacity:    pq.capacity,
+		evictLowest: pq.evictLowest,
+	}
+	for _, item := range items {
+		if !pred(item.Value) {
+			continue
+		}
+		clone := &Item[T]{Value: item.Value, Priority: item.Priority, Index: len(result.items), seq: item.seq}
+		result.items = append(result.items, clone)
+	}
+	if result.bounded {
+		result.mmBuildHeap()
+	} else {
+		heap.Init(result)
+	}
+	return result
+}
@@ -0,0 +1,125 @@
+package prioqueue
+
+import "testing"
+
+func TestIteratorDoesNotDrainQueue(t *testing.T) {
+	pq := New[string]()
+	pq.Enqueue("low", 3)
+	pq.Enqueue("high", 1)
+	pq.Enqueue("medium", 2)
+
+	it := pq.Iterator()
+	var seen []string
+	for {
+		item, ok := it.Next()
+		if !ok {
+			break
+		}
+		seen = append(seen, item.Value)
+	}
+
+	want := []string{"high", "medium", "low"}
+	if len(seen) != len(want) {
+		t.Fatalf("Expected %d items, got %d", len(want), len(seen))
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Errorf("Expected %q at position %d, got %q", want[i], i, seen[i])
+		}
+	}
+
+	if pq.Size() != 3 {
+		t.Errorf("Expected original queue untouched, size %d", pq.Size())
+	}
+}
+
+func TestSorted(t *testing.T) {
+	pq := NewMax[int]()
+	pq.Enqueue(1, 1)
+	pq.Enqueue(2, 5)
+	pq.Enqueue(3, 3)
+
+	sorted := pq.Sorted()
+	if len(sorted) != 3 {
+		t.Fatalf("Expected 3 items, got %d", len(sorted))
+	}
+	for i, want := range []int{5, 3, 1} {
+		if sorted[i].Priority != want {
+			t.Errorf("Expected priority %d at position %d, got %d", want, i, sorted[i].Priority)
+		}
+	}
+}
+
+func TestRangeEarlyTermination(t *testing.T) {
+	pq := New[int]()
+	for i := 1; i <= 5; i++ {
+		pq.Enqueue(i, i)
+	}
+
+	var seen []int
+	pq.Range(func(item Item[int]) bool {
+		seen = append(seen, item.Value)
+		return len(seen) < 2
+	})
+
+	if len(seen) != 2 {
+		t.Fatalf("Expected Range to stop after 2 items, got %d", len(seen))
+	}
+	if seen[0] != 1 || seen[1] != 2 {
+		t.Errorf("Expected [1 2], got %v", seen)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	pq := New[int]()
+	for i := 1; i <= 5; i++ {
+		pq.Enqueue(i, i)
+	}
+
+	evens := pq.Filter(func(v int) bool { return v%2 == 0 })
+
+	if evens.Size() != 2 {
+		t.Fatalf("Expected 2 even items, got %d", evens.Size())
+	}
+	for _, want := range []int{2, 4} {
+		value, ok := evens.Dequeue()
+		if !ok || value != want {
+			t.Errorf("Expected %d, got %v", want, value)
+		}
+	}
+	if pq.Size() != 5 {
+		t.Errorf("Expected original queue untouched, size %d", pq.Size())
+	}
+}
+
+func TestFilterPreservesBoundedCapacity(t *testing.T) {
+	pq := NewBounded[int](10, true)
+	for i := 1; i <= 5; i++ {
+		pq.Enqueue(i, i)
+	}
+
+	evens := pq.Filter(func(v int) bool { return v%2 == 0 })
+
+	if evens.Size() != 2 {
+		t.Fatalf("Expected 2 even items, got %d", evens.Size())
+	}
+
+	// On a queue that lost its bounded-ness PeekMin/PeekMax report false,
+	// so exercise both ends.
+	min, ok := evens.PeekMin()
+	if !ok || min != 2 {
+		t.Errorf("Expected filtered queue to stay bounded with min 2, got %v", min)
+	}
+	max, ok := evens.PeekMax()
+	if !ok || max != 4 {
+		t.Errorf("Expected filtered queue to stay bounded with max 4, got %v", max)
+	}
+
+	item, evicted := evens.EnqueueEvicting(6, 6)
+	if item == nil || item.Value != 6 {
+		t.Fatalf("Expected inserted item 6, got %v", item)
+	}
+	if evicted != nil {
+		t.Errorf("Expected no eviction while under capacity 10, got %v", evicted)
+	}
+}
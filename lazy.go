@@ -0,0 +1,272 @@
+package prioqueue
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// lazyNode holds one enqueued value plus its position in both of
+// LazyPriorityQueue's internal heaps.
+type lazyNode[T any] struct {
+	value T
+
+	curPriority int64
+	curIndex    int
+
+	maxPriority int64
+	maxIndex    int
+}
+
+// lazyCurHeap orders nodes by their last-computed current priority,
+// highest first.
+type lazyCurHeap[T any] []*lazyNode[T]
+
+func (h lazyCurHeap[T]) Len() int            { return len(h) }
+func (h lazyCurHeap[T]) Less(i, j int) bool  { return h[i].curPriority > h[j].curPriority }
+func (h lazyCurHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].curIndex = i
+	h[j].curIndex = j
+}
+
+func (h *lazyCurHeap[T]) Push(x interface{}) {
+	node := x.(*lazyNode[T])
+	node.curIndex = len(*h)
+	*h = append(*h, node)
+}
+
+func (h *lazyCurHeap[T]) Pop() interface{} {
+	old := *h
+	n := len(old)
+	node := old[n-1]
+	old[n-1] = nil
+	node.curIndex = -1
+	*h = old[:n-1]
+	return node
+}
+
+// lazyMaxHeap orders nodes by their estimated upper-bound priority,
+// highest first.
+type lazyMaxHeap[T any] []*lazyNode[T]
+
+func (h lazyMaxHeap[T]) Len() int           { return len(h) }
+func (h lazyMaxHeap[T]) Less(i, j int) bool { return h[i].maxPriority > h[j].maxPriority }
+func (h lazyMaxHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].maxIndex = i
+	h[j].maxIndex = j
+}
+
+func (h *lazyMaxHeap[T]) Push(x interface{}) {
+	node := x.(*lazyNode[T])
+	node.maxIndex = len(*h)
+	*h = append(*h, node)
+}
+
+func (h *lazyMaxHeap[T]) Pop() interface{} {
+	old := *h
+	n := len(old)
+	node := old[n-1]
+	old[n-1] = nil
+	node.maxIndex = -1
+	*h = old[:n-1]
+	return node
+}
+
+// bestExcluding returns the highest-maxPriority node other than node
+// itself, or nil if node is the only item in the heap. When node isn't
+// the heap's root, the root itself is the answer; when it is, the
+// second-largest element of a max-heap is always one of the root's two
+// children, so no full scan is needed.
+func (h lazyMaxHeap[T]) bestExcluding(node *lazyNode[T]) *lazyNode[T] {
+	if len(h) == 0 || (len(h) == 1 && h[0] == node) {
+		return nil
+	}
+	if h[0] != node {
+		return h[0]
+	}
+	if len(h) == 2 {
+		return h[1]
+	}
+	best := h[1]
+	if h[2].maxPriority > best.maxPriority {
+		best = h[2]
+	}
+	return best
+}
+
+// LazyPriorityQueue is for workloads where an item's priority is a
+// function of time or external state and changes continuously (token
+// buckets, TTL-based reprioritization, connection quality scoring). It
+// avoids recomputing and re-heapifying on every item for every tick by
+// keeping a second heap of priorityMax upper-bound estimates: Dequeue
+// recomputes priorityNow for the current leader only, and moves on to
+// other items only when the max-estimate heap suggests one of them might
+// now outrank it (recomputing every item if that does not settle).
+type LazyPriorityQueue[T any] struct {
+	priorityNow func(T) int64
+	priorityMax func(T, time.Time) int64
+
+	cur   lazyCurHeap[T]
+	max   lazyMaxHeap[T]
+	mutex sync.Mutex
+}
+
+// NewLazyPriorityQueue creates a LazyPriorityQueue. priorityNow computes
+// an item's true current priority; priorityMax computes an upper bound
+// on that priority that remains valid until some point in the future
+// (e.g. the next time a TTL-based score can only have decayed further).
+func NewLazyPriorityQueue[T any](priorityNow func(T) int64, priorityMax func(T, time.Time) int64) *LazyPriorityQueue[T] {
+	lpq := &LazyPriorityQueue[T]{
+		priorityNow: priorityNow,
+		priorityMax: priorityMax,
+	}
+	heap.Init(&lpq.cur)
+	heap.Init(&lpq.max)
+	return lpq
+}
+
+// Enqueue adds value, computing its initial current priority and
+// max-estimate priority from the callbacks supplied to
+// NewLazyPriorityQueue.
+func (lpq *LazyPriorityQueue[T]) Enqueue(value T) {
+	lpq.mutex.Lock()
+	defer lpq.mutex.Unlock()
+
+	now := time.Now()
+	node := &lazyNode[T]{
+		value:       value,
+		curPriority: lpq.priorityNow(value),
+		maxPriority: lpq.priorityMax(value, now),
+	}
+	heap.Push(&lpq.cur, node)
+	heap.Push(&lpq.max, node)
+}
+
+// Peek returns the highest-priority value without removing it,
+// refreshing stale priorities along the way exactly as Dequeue does.
+func (lpq *LazyPriorityQueue[T]) Peek() (T, bool) {
+	lpq.mutex.Lock()
+	defer lpq.mutex.Unlock()
+
+	node, ok := lpq.resolveTop()
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	heap.Push(&lpq.cur, node)
+	return node.value, true
+}
+
+// Dequeue removes and returns the highest-priority value.
+func (lpq *LazyPriorityQueue[T]) Dequeue() (T, bool) {
+	lpq.mutex.Lock()
+	defer lpq.mutex.Unlock()
+
+	node, ok := lpq.resolveTop()
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	if node.maxIndex >= 0 && node.maxIndex < lpq.max.Len() {
+		heap.Remove(&lpq.max, node.maxIndex)
+	}
+	return node.value, true
+}
+
+// resolveTop pops the current-priority heap's top, refreshes it via
+// priorityNow, and checks it against the highest max-estimate held by
+// any *other* item (comparing a node against its own estimate is
+// meaningless: the estimate is a loose bound on the node's own value,
+// not a rival's). Since the refreshed value can only lower the node's
+// bound, the check is tightened onto the max-estimate heap too, so the
+// comparison converges instead of comparing the same loose bound
+// forever. If the node still doesn't dominate, it's reinserted and the
+// process retries against whichever item is now on top. The returned
+// node has already been removed from cur; the caller decides whether to
+// also remove it from max (Dequeue) or push it back (Peek).
+func (lpq *LazyPriorityQueue[T]) resolveTop() (*lazyNode[T], bool) {
+	attempts, budget := 0, lpq.cur.Len()
+	for {
+		if lpq.cur.Len() == 0 {
+			return nil, false
+		}
+		top := heap.Pop(&lpq.cur).(*lazyNode[T])
+
+		top.curPriority = lpq.priorityNow(top.value)
+		if top.curPriority < top.maxPriority {
+			top.maxPriority = top.curPriority
+			if top.maxIndex >= 0 && top.maxIndex < lpq.max.Len() {
+				heap.Fix(&lpq.max, top.maxIndex)
+			}
+		}
+
+		best := lpq.max.bestExcluding(top)
+		if best == nil || top.curPriority >= best.maxPriority {
+			return top, true
+		}
+
+		heap.Push(&lpq.cur, top)
+		if attempts++; attempts > budget {
+			// The leader-only refresh above isn't making progress: the
+			// true winner never surfaces as cur's top because its
+			// cached priority still trails a stale max-estimate held by
+			// someone else. Resolve everyone at once, which always
+			// terminates, exactly like Refresh does.
+			return lpq.resolveByFullRefresh()
+		}
+	}
+}
+
+// resolveByFullRefresh recomputes every item's priorities and pops the
+// resulting top. It's the fallback resolveTop reaches for when
+// repeatedly refreshing just the current leader fails to converge.
+func (lpq *LazyPriorityQueue[T]) resolveByFullRefresh() (*lazyNode[T], bool) {
+	if lpq.cur.Len() == 0 {
+		return nil, false
+	}
+	now := time.Now()
+	for _, node := range lpq.cur {
+		node.curPriority = lpq.priorityNow(node.value)
+		node.maxPriority = lpq.priorityMax(node.value, now)
+	}
+	heap.Init(&lpq.cur)
+	heap.Init(&lpq.max)
+	return heap.Pop(&lpq.cur).(*lazyNode[T]), true
+}
+
+// Refresh recomputes every item's current and max-estimate priority and
+// rebuilds both heaps in O(n), avoiding the need to call UpdatePriority
+// on each item individually when priorities drift continuously.
+func (lpq *LazyPriorityQueue[T]) Refresh() {
+	lpq.mutex.Lock()
+	defer lpq.mutex.Unlock()
+
+	now := time.Now()
+	for _, node := range lpq.cur {
+		node.curPriority = lpq.priorityNow(node.value)
+		node.maxPriority = lpq.priorityMax(node.value, now)
+	}
+	heap.Init(&lpq.cur)
+	heap.Init(&lpq.max)
+}
+
+// Len returns the number of items in the queue.
+func (lpq *LazyPriorityQueue[T]) Len() int {
+	return lpq.cur.Len()
+}
+
+// Size returns the number of items in the queue.
+func (lpq *LazyPriorityQueue[T]) Size() int {
+	lpq.mutex.Lock()
+	defer lpq.mutex.Unlock()
+	return lpq.cur.Len()
+}
+
+// IsEmpty returns true if the queue holds no items.
+func (lpq *LazyPriorityQueue[T]) IsEmpty() bool {
+	lpq.mutex.Lock()
+	defer lpq.mutex.Unlock()
+	return lpq.cur.Len() == 0
+}
@@ -0,0 +1,116 @@
+package prioqueue
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLazyPriorityQueueBasic(t *testing.T) {
+	scores := map[string]int64{"a": 1, "b": 5, "c": 3}
+	priorityNow := func(v string) int64 { return scores[v] }
+	priorityMax := func(v string, now time.Time) int64 { return scores[v] }
+
+	lpq := NewLazyPriorityQueue(priorityNow, priorityMax)
+	lpq.Enqueue("a")
+	lpq.Enqueue("b")
+	lpq.Enqueue("c")
+
+	if lpq.Size() != 3 {
+		t.Fatalf("Expected size 3, got %d", lpq.Size())
+	}
+
+	value, ok := lpq.Peek()
+	if !ok || value != "b" {
+		t.Errorf("Expected peek 'b', got %v", value)
+	}
+
+	for _, want := range []string{"b", "c", "a"} {
+		value, ok := lpq.Dequeue()
+		if !ok || value != want {
+			t.Errorf("Expected %q, got %v", want, value)
+		}
+	}
+
+	if !lpq.IsEmpty() {
+		t.Error("Expected queue to be empty")
+	}
+}
+
+func TestLazyPriorityQueueRefreshesStalePriority(t *testing.T) {
+	// "b" starts highest, but its true priority decays over time while
+	// its recorded max-estimate doesn't, forcing Dequeue to refresh it
+	// and discover "a" is now ahead.
+	scores := map[string]int64{"a": 2, "b": 10}
+	priorityNow := func(v string) int64 { return scores[v] }
+	priorityMax := func(v string, now time.Time) int64 { return 10 }
+
+	lpq := NewLazyPriorityQueue(priorityNow, priorityMax)
+	lpq.Enqueue("a")
+	lpq.Enqueue("b")
+
+	scores["b"] = 0 // simulate external decay after enqueue
+
+	value, ok := lpq.Dequeue()
+	if !ok || value != "a" {
+		t.Errorf("Expected 'a' after stale priority refresh, got %v", value)
+	}
+
+	value, ok = lpq.Dequeue()
+	if !ok || value != "b" {
+		t.Errorf("Expected 'b', got %v", value)
+	}
+}
+
+func TestLazyPriorityQueueSingleItemDoesNotHang(t *testing.T) {
+	// A single enqueued item is always its own max-estimate heap's root,
+	// so resolveTop must not compare it against its own loose bound.
+	scores := map[string]int64{"a": 1}
+	priorityNow := func(v string) int64 { return scores[v] }
+	priorityMax := func(v string, now time.Time) int64 { return 100 }
+
+	lpq := NewLazyPriorityQueue(priorityNow, priorityMax)
+	lpq.Enqueue("a")
+
+	value, ok := lpq.Dequeue()
+	if !ok || value != "a" {
+		t.Errorf("Expected 'a', got %v", value)
+	}
+}
+
+func TestLazyPriorityQueueHiddenCompetitorConverges(t *testing.T) {
+	// "z" trails behind "x" on cached priority so it never surfaces as
+	// cur's top, yet its stale max-estimate outranks x's real value.
+	// resolveTop must still converge instead of endlessly refreshing x.
+	scores := map[string]int64{"x": 5, "z": 2}
+	priorityMax := map[string]int64{"x": 8, "z": 100}
+	priorityNow := func(v string) int64 { return scores[v] }
+	priorityMaxFn := func(v string, now time.Time) int64 { return priorityMax[v] }
+
+	lpq := NewLazyPriorityQueue(priorityNow, priorityMaxFn)
+	lpq.Enqueue("x")
+	lpq.Enqueue("z")
+	scores["z"] = 1 // "z"'s true value has decayed below its stale bound
+
+	value, ok := lpq.Dequeue()
+	if !ok || value != "x" {
+		t.Errorf("Expected 'x', got %v", value)
+	}
+}
+
+func TestLazyPriorityQueueRefresh(t *testing.T) {
+	scores := map[string]int64{"a": 1, "b": 2}
+	priorityNow := func(v string) int64 { return scores[v] }
+	priorityMax := func(v string, now time.Time) int64 { return scores[v] }
+
+	lpq := NewLazyPriorityQueue(priorityNow, priorityMax)
+	lpq.Enqueue("a")
+	lpq.Enqueue("b")
+
+	scores["a"] = 5
+	lpq.Refresh()
+
+	value, ok := lpq.Peek()
+	if !ok || value != "a" {
+		t.Errorf("Expected 'a' to lead after Refresh, got %v", value)
+	}
+}
@@ -0,0 +1,253 @@
+package prioqueue
+
+import (
+	"container/heap"
+	"unsafe"
+)
+
+// pairingNode is a node in the pairing heap used by pairing-backed queues
+// (see NewPairing). Each node keeps a parent link plus a child/sibling
+// linked list so that an arbitrary node can be detached from the tree and
+// re-merged with the root in O(log n) amortized time, which is what
+// UpdatePriority and Remove need for decrease-key style operations.
+type pairingNode[T any] struct {
+	item    *Item[T]
+	parent  *pairingNode[T]
+	child   *pairingNode[T]
+	sibling *pairingNode[T]
+}
+
+// pairingMerge merges two root nodes into one, making the lower-priority
+// root a child of the higher-priority one. Either argument may be nil.
+func (pq *PriorityQueue[T]) pairingMerge(a, b *pairingNode[T]) *pairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if pq.less(b.item, a.item) {
+		a, b = b, a
+	}
+	b.parent = a
+	b.sibling = a.child
+	a.child = b
+	return a
+}
+
+// pairingInsert creates a node wrapping item and merges it into root,
+// recording the node on item so later UpdatePriority/Remove calls can
+// find it again.
+func (pq *PriorityQueue[T]) pairingInsert(root *pairingNode[T], item *Item[T]) *pairingNode[T] {
+	node := &pairingNode[T]{item: item}
+	item.node = node
+	return pq.pairingMerge(root, node)
+}
+
+// pairingMergePairs implements the classic two-pass pairing merge used to
+// rebuild a heap from a list of sibling trees (e.g. a removed root's
+// children).
+func (pq *PriorityQueue[T]) pairingMergePairs(first *pairingNode[T]) *pairingNode[T] {
+	if first == nil || first.sibling == nil {
+		if first != nil {
+			first.sibling = nil
+			first.parent = nil
+		}
+		return first
+	}
+
+	a := first
+	b := first.sibling
+	rest := b.sibling
+
+	a.sibling = nil
+	a.parent = nil
+	b.sibling = nil
+	b.parent = nil
+
+	return pq.pairingMerge(pq.pairingMerge(a, b), pq.pairingMergePairs(rest))
+}
+
+// pairingDeleteMin removes and returns the root node, rebuilding the heap
+// from its former children.
+func (pq *PriorityQueue[T]) pairingDeleteMin() *pairingNode[T] {
+	root := pq.root
+	if root == nil {
+		return nil
+	}
+	pq.root = pq.pairingMergePairs(root.child)
+	root.child, root.sibling, root.parent = nil, nil, nil
+	pq.count--
+	return root
+}
+
+// pairingDetach unlinks node from its parent's child list, leaving node
+// (and its own subtree) standalone.
+func (pq *PriorityQueue[T]) pairingDetach(node *pairingNode[T]) {
+	parent := node.parent
+	if parent == nil {
+		return
+	}
+	if parent.child == node {
+		parent.child = node.sibling
+	} else {
+		sibling := parent.child
+		for sibling.sibling != node {
+			sibling = sibling.sibling
+		}
+		sibling.sibling = node.sibling
+	}
+	node.sibling = nil
+	node.parent = nil
+}
+
+// pairingUpdateKey repositions node after its item's priority has changed
+// in either direction: it detaches node from wherever it sits in the
+// tree, folds its children back into the heap, then re-merges the
+// (now childless) node as a fresh singleton so the heap property is
+// restored regardless of whether the key improved or worsened.
+func (pq *PriorityQueue[T]) pairingUpdateKey(node *pairingNode[T]) {
+	if node == pq.root {
+		pq.root = pq.pairingMergePairs(node.child)
+		node.child = nil
+		pq.root = pq.pairingMerge(pq.root, node)
+		return
+	}
+
+	pq.pairingDetach(node)
+	merged := pq.pairingMergePairs(node.child)
+	node.child = nil
+	pq.root = pq.pairingMerge(pq.root, merged)
+	pq.root = pq.pairingMerge(pq.root, node)
+}
+
+// pairingRemoveNode removes an arbitrary node from the heap, discarding
+// its item and folding its children back in.
+func (pq *PriorityQueue[T]) pairingRemoveNode(node *pairingNode[T]) {
+	if node == pq.root {
+		pq.pairingDeleteMin()
+		return
+	}
+
+	pq.pairingDetach(node)
+	merged := pq.pairingMergePairs(node.child)
+	node.child = nil
+	pq.root = pq.pairingMerge(pq.root, merged)
+	pq.count--
+}
+
+// pairingCollect walks every node reachable from root and returns their
+// items in tree order (not priority order).
+func (pq *PriorityQueue[T]) pairingCollect() []*Item[T] {
+	var result []*Item[T]
+	var walk func(node *pairingNode[T])
+	walk = func(node *pairingNode[T]) {
+		for n := node; n != nil; n = n.sibling {
+			result = append(result, n.item)
+			if n.child != nil {
+				walk(n.child)
+			}
+		}
+	}
+	if pq.root != nil {
+		walk(pq.root)
+	}
+	return result
+}
+
+// extractAll removes every item from pq, regardless of whether it is
+// binary-heap- or pairing-heap-backed, leaving pq empty.
+func (pq *PriorityQueue[T]) extractAll() []*Item[T] {
+	if pq.pairing {
+		items := pq.pairingCollect()
+		pq.root, pq.count = nil, 0
+		for _, item := range items {
+			item.node = nil
+		}
+		return items
+	}
+
+	items := pq.items
+	pq.items = nil
+	for _, item := range items {
+		item.Index = -1
+	}
+	return items
+}
+
+// lockBoth locks pq's and other's mutexes in a consistent global order
+// (by pointer address, with a self-meld locking just once) so that two
+// goroutines concurrently melding the same pair in opposite directions
+// (a.Meld(b) and b.Meld(a)) can never deadlock via AB-BA lock ordering.
+// It returns a function that unlocks in the reverse order.
+func (pq *PriorityQueue[T]) lockBoth(other *PriorityQueue[T]) func() {
+	if pq == other {
+		pq.mutex.Lock()
+		return pq.mutex.Unlock
+	}
+	first, second := pq, other
+	if uintptr(unsafe.Pointer(other)) < uintptr(unsafe.Pointer(pq)) {
+		first, second = other, pq
+	}
+	first.mutex.Lock()
+	second.mutex.Lock()
+	return func() {
+		second.mutex.Unlock()
+		first.mutex.Unlock()
+	}
+}
+
+// Meld merges pq and other into a newly allocated priority queue, leaving
+// both pq and other empty. pq and other must share the same comparator
+// and min/max orientation. When both queues are pairing-heap-backed (see
+// NewPairing) the merge is a single O(1) pointer operation; otherwise it
+// falls back to draining both queues and rebuilding via repeated Enqueue,
+// which costs O(n log n).
+func (pq *PriorityQueue[T]) Meld(other *PriorityQueue[T]) *PriorityQueue[T] {
+	unlock := pq.lockBoth(other)
+	defer unlock()
+
+	if pq.pairing && other.pairing {
+		result := &PriorityQueue[T]{comparator: pq.comparator, isMaxHeap: pq.isMaxHeap, pairing: true}
+		result.root = result.pairingMerge(pq.root, other.root)
+		result.count = pq.count + other.count
+		pq.root, pq.count = nil, 0
+		other.root, other.count = nil, 0
+		return result
+	}
+
+	result := &PriorityQueue[T]{comparator: pq.comparator, isMaxHeap: pq.isMaxHeap}
+	heap.Init(result)
+	for _, item := range pq.extractAll() {
+		heap.Push(result, &Item[T]{Value: item.Value, Priority: item.Priority})
+	}
+	for _, item := range other.extractAll() {
+		heap.Push(result, &Item[T]{Value: item.Value, Priority: item.Priority})
+	}
+	return result
+}
+
+// MeldInto merges other into pq in place and leaves other empty, without
+// allocating a new queue. Like Meld, it runs in O(1) when pq and other
+// are both pairing-heap-backed and falls back to O(n log n) otherwise.
+func (pq *PriorityQueue[T]) MeldInto(other *PriorityQueue[T]) {
+	unlock := pq.lockBoth(other)
+	defer unlock()
+	defer pq.ensureCond().Broadcast()
+
+	if pq.pairing && other.pairing {
+		pq.root = pq.pairingMerge(pq.root, other.root)
+		pq.count += other.count
+		other.root, other.count = nil, 0
+		return
+	}
+
+	for _, item := range other.extractAll() {
+		if pq.pairing {
+			pq.root = pq.pairingInsert(pq.root, &Item[T]{Value: item.Value, Priority: item.Priority})
+			pq.count++
+		} else {
+			heap.Push(pq, &Item[T]{Value: item.Value, Priority: item.Priority})
+		}
+	}
+}
@@ -14,6 +14,9 @@ type Item[T any] struct {
 	Value    T
 	Priority int
 	Index    int // The index of the item in the heap
+
+	node *pairingNode[T] // set when the owning queue is pairing-heap-backed
+	seq  uint64          // enqueue order, used for tie-breaking when stable is true
 }
 
 // PriorityQueue is a generic priority queue implementation
@@ -22,6 +25,28 @@ type PriorityQueue[T any] struct {
 	comparator Comparator[T]
 	isMaxHeap  bool
 	mutex      sync.RWMutex
+
+	pairing bool            // true if backed by a pairing heap instead of items
+	root    *pairingNode[T] // pairing heap root, used only when pairing is true
+	count   int             // item count, used only when pairing is true
+
+	bounded     bool // true if items is kept as a min-max heap with a capacity
+	capacity    int  // max size once bounded is true; <= 0 means unlimited
+	evictLowest bool // which end Enqueue evicts from when over capacity
+
+	// stable makes items with equal priority dequeue in insertion (FIFO)
+	// order. seqCounter is bumped under mutex on every Enqueue; at one
+	// increment per Enqueue call, wrapping a uint64 counter is not a
+	// practical concern.
+	stable     bool
+	seqCounter uint64
+
+	cond     *sync.Cond // lazily built by ensureCond; guards blocking consumers
+	condOnce sync.Once
+
+	chanOnce   sync.Once
+	chanOut    chan T
+	chanCancel func()
 }
 
 // New creates a new priority queue with default integer comparison (min-heap)
@@ -55,26 +80,86 @@ func NewWithComparator[T any](comparator Comparator[T], isMaxHeap bool) *Priorit
 	return pq
 }
 
+// NewPairing creates a new pairing-heap-backed priority queue (min-heap).
+// Unlike the binary-heap queues returned by New/NewMax/NewWithComparator,
+// pairing heaps support Meld/MeldInto in O(1)/O(log n) amortized time
+// instead of falling back to repeated Enqueue.
+func NewPairing[T any]() *PriorityQueue[T] {
+	return &PriorityQueue[T]{pairing: true}
+}
+
+// NewStable creates a new priority queue (min-heap) in which items with
+// equal priority dequeue in the order they were enqueued, matching the
+// behavior Rosetta Code's priority-queue task describes for ties.
+func NewStable[T any]() *PriorityQueue[T] {
+	pq := &PriorityQueue[T]{
+		items:  make([]*Item[T], 0),
+		stable: true,
+	}
+	heap.Init(pq)
+	return pq
+}
+
 // Len returns the number of items in the priority queue
 func (pq *PriorityQueue[T]) Len() int {
+	if pq.pairing {
+		return pq.count
+	}
 	return len(pq.items)
 }
 
-// Less compares two items based on priority
-func (pq *PriorityQueue[T]) Less(i, j int) bool {
+// compareAsc orders a against b in ascending order, before isMaxHeap
+// flips it for the caller's extraction direction: by comparator if one
+// is set, otherwise by Priority.
+func (pq *PriorityQueue[T]) compareAsc(a, b *Item[T]) int {
 	if pq.comparator != nil {
-		result := pq.comparator(pq.items[i].Value, pq.items[j].Value)
-		if pq.isMaxHeap {
-			return result > 0
-		}
-		return result < 0
+		return pq.comparator(a.Value, b.Value)
+	}
+	switch {
+	case a.Priority < b.Priority:
+		return -1
+	case a.Priority > b.Priority:
+		return 1
+	default:
+		return 0
 	}
+}
 
-	// Default comparison by priority
+// less reports whether a has higher priority than b under pq's ordering.
+// It is shared by the binary heap's Less and the pairing heap helpers.
+func (pq *PriorityQueue[T]) less(a, b *Item[T]) bool {
+	result := pq.compareAsc(a, b)
+	if result == 0 {
+		if pq.stable {
+			return a.seq < b.seq
+		}
+		return false
+	}
 	if pq.isMaxHeap {
-		return pq.items[i].Priority > pq.items[j].Priority
+		return result > 0
 	}
-	return pq.items[i].Priority < pq.items[j].Priority
+	return result < 0
+}
+
+// mmLess reports whether a sorts before b in the min-max heap's fixed
+// ascending order (comparator if set, else Priority), honoring stable's
+// FIFO tie-break. Unlike less, it ignores isMaxHeap: the min-max heap
+// always maintains both ascending extremes at once, so PeekMin/PeekMax
+// need one direction-independent ordering. isMaxHeap only selects which
+// of those two extremes Peek/Dequeue/eviction reach for.
+func (pq *PriorityQueue[T]) mmLess(a, b *Item[T]) bool {
+	if result := pq.compareAsc(a, b); result != 0 {
+		return result < 0
+	}
+	if pq.stable {
+		return a.seq < b.seq
+	}
+	return false
+}
+
+// Less compares two items based on priority
+func (pq *PriorityQueue[T]) Less(i, j int) bool {
+	return pq.less(pq.items[i], pq.items[j])
 }
 
 // Swap swaps two items in the priority queue
@@ -103,25 +188,89 @@ func (pq *PriorityQueue[T]) Pop() interface{} {
 	return item
 }
 
-// Enqueue adds an item with given priority
-func (pq *PriorityQueue[T]) Enqueue(value T, priority int) *Item[T] {
-	pq.mutex.Lock()
-	defer pq.mutex.Unlock()
+// enqueue inserts value/priority and, for a bounded queue that has grown
+// past capacity, evicts and returns the item at the configured eviction
+// end. Callers must hold pq.mutex.
+func (pq *PriorityQueue[T]) enqueue(value T, priority int) (item *Item[T], evicted *Item[T]) {
+	defer pq.ensureCond().Broadcast()
 
-	item := &Item[T]{
+	item = &Item[T]{
 		Value:    value,
 		Priority: priority,
 	}
+	if pq.stable {
+		pq.seqCounter++
+		item.seq = pq.seqCounter
+	}
+
+	if pq.pairing {
+		pq.root = pq.pairingInsert(pq.root, item)
+		pq.count++
+		return item, nil
+	}
+
+	if pq.bounded {
+		pq.mmInsert(item)
+		if pq.capacity > 0 && len(pq.items) > pq.capacity {
+			if pq.evictLowest {
+				evicted = pq.mmRemoveAt(0)
+			} else {
+				evicted = pq.mmRemoveAt(pq.mmMaxIndex())
+			}
+		}
+		return item, evicted
+	}
+
 	heap.Push(pq, item)
+	return item, nil
+}
+
+// Enqueue adds an item with given priority
+func (pq *PriorityQueue[T]) Enqueue(value T, priority int) *Item[T] {
+	pq.mutex.Lock()
+	defer pq.mutex.Unlock()
+
+	item, _ := pq.enqueue(value, priority)
 	return item
 }
 
-// Dequeue removes and returns the highest priority item
-func (pq *PriorityQueue[T]) Dequeue() (T, bool) {
+// EnqueueEvicting behaves like Enqueue but additionally returns the item
+// evicted to respect a bounded queue's capacity (see NewBounded/
+// SetCapacity), if any. On a non-bounded queue, or one still under
+// capacity, evicted is nil.
+func (pq *PriorityQueue[T]) EnqueueEvicting(value T, priority int) (item *Item[T], evicted *Item[T]) {
 	pq.mutex.Lock()
 	defer pq.mutex.Unlock()
 
+	return pq.enqueue(value, priority)
+}
+
+// dequeueLocked pops the highest priority item. Callers must hold
+// pq.mutex.Lock.
+func (pq *PriorityQueue[T]) dequeueLocked() (T, bool) {
 	var zero T
+	if pq.pairing {
+		node := pq.pairingDeleteMin()
+		if node == nil {
+			return zero, false
+		}
+		node.item.node = nil
+		return node.item.Value, true
+	}
+
+	if pq.bounded {
+		var item *Item[T]
+		if pq.isMaxHeap {
+			item = pq.mmPopMax()
+		} else {
+			item = pq.mmPopMin()
+		}
+		if item == nil {
+			return zero, false
+		}
+		return item.Value, true
+	}
+
 	if len(pq.items) == 0 {
 		return zero, false
 	}
@@ -130,16 +279,34 @@ func (pq *PriorityQueue[T]) Dequeue() (T, bool) {
 	return item.Value, true
 }
 
+// Dequeue removes and returns the highest priority item
+func (pq *PriorityQueue[T]) Dequeue() (T, bool) {
+	pq.mutex.Lock()
+	defer pq.mutex.Unlock()
+	return pq.dequeueLocked()
+}
+
 // Peek returns the highest priority item without removing it
 func (pq *PriorityQueue[T]) Peek() (T, bool) {
 	pq.mutex.RLock()
 	defer pq.mutex.RUnlock()
 
 	var zero T
+	if pq.pairing {
+		if pq.root == nil {
+			return zero, false
+		}
+		return pq.root.item.Value, true
+	}
+
 	if len(pq.items) == 0 {
 		return zero, false
 	}
 
+	if pq.bounded && pq.isMaxHeap {
+		return pq.items[pq.mmMaxIndex()].Value, true
+	}
+
 	return pq.items[0].Value, true
 }
 
@@ -147,6 +314,9 @@ func (pq *PriorityQueue[T]) Peek() (T, bool) {
 func (pq *PriorityQueue[T]) IsEmpty() bool {
 	pq.mutex.RLock()
 	defer pq.mutex.RUnlock()
+	if pq.pairing {
+		return pq.count == 0
+	}
 	return len(pq.items) == 0
 }
 
@@ -159,6 +329,11 @@ func (pq *PriorityQueue[T]) Size() int {
 func (pq *PriorityQueue[T]) Clear() {
 	pq.mutex.Lock()
 	defer pq.mutex.Unlock()
+	if pq.pairing {
+		pq.root = nil
+		pq.count = 0
+		return
+	}
 	pq.items = pq.items[:0]
 	heap.Init(pq)
 }
@@ -168,11 +343,26 @@ func (pq *PriorityQueue[T]) UpdatePriority(item *Item[T], newPriority int) {
 	pq.mutex.Lock()
 	defer pq.mutex.Unlock()
 
+	if pq.pairing {
+		if item.node == nil {
+			return
+		}
+		item.Priority = newPriority
+		pq.pairingUpdateKey(item.node)
+		return
+	}
+
 	if item.Index < 0 || item.Index >= len(pq.items) {
 		return
 	}
 
 	item.Priority = newPriority
+
+	if pq.bounded {
+		pq.mmPushUp(pq.mmPushDown(item.Index))
+		return
+	}
+
 	heap.Fix(pq, item.Index)
 }
 
@@ -182,10 +372,25 @@ func (pq *PriorityQueue[T]) Remove(item *Item[T]) (T, bool) {
 	defer pq.mutex.Unlock()
 
 	var zero T
+	if pq.pairing {
+		if item.node == nil {
+			return zero, false
+		}
+		node := item.node
+		pq.pairingRemoveNode(node)
+		node.item.node = nil
+		return node.item.Value, true
+	}
+
 	if item.Index < 0 || item.Index >= len(pq.items) {
 		return zero, false
 	}
 
+	if pq.bounded {
+		removed := pq.mmRemoveAt(item.Index)
+		return removed.Value, true
+	}
+
 	removed := heap.Remove(pq, item.Index).(*Item[T])
 	return removed.Value, true
 }
@@ -195,8 +400,13 @@ func (pq *PriorityQueue[T]) ToSlice() []Item[T] {
 	pq.mutex.RLock()
 	defer pq.mutex.RUnlock()
 
-	result := make([]Item[T], len(pq.items))
-	for i, item := range pq.items {
+	items := pq.items
+	if pq.pairing {
+		items = pq.pairingCollect()
+	}
+
+	result := make([]Item[T], len(items))
+	for i, item := range items {
 		result[i] = *item
 	}
 	return result
@@ -207,7 +417,12 @@ func (pq *PriorityQueue[T]) String() string {
 	pq.mutex.RLock()
 	defer pq.mutex.RUnlock()
 
-	if len(pq.items) == 0 {
+	items := pq.items
+	if pq.pairing {
+		items = pq.pairingCollect()
+	}
+
+	if len(items) == 0 {
 		return "PriorityQueue[]"
 	}
 
@@ -217,8 +432,8 @@ func (pq *PriorityQueue[T]) String() string {
 		heapType = "max"
 	}
 
-	result = fmt.Sprintf("PriorityQueue[%s-heap, size=%d]:", heapType, len(pq.items))
-	for i, item := range pq.items {
+	result = fmt.Sprintf("PriorityQueue[%s-heap, size=%d]:", heapType, len(items))
+	for i, item := range items {
 		result += fmt.Sprintf("\n  [%d] Value: %v, Priority: %d", i, item.Value, item.Priority)
 	}
 	return result
@@ -2,7 +2,9 @@ package prioqueue
 
 import (
 	"fmt"
+	"math/rand"
 	"testing"
+	"time"
 )
 
 func TestNewPriorityQueue(t *testing.T) {
@@ -358,6 +360,341 @@ func ExamplePriorityQueue() {
 	// Processing: low priority task
 }
 
+func TestPairingEnqueueDequeue(t *testing.T) {
+	pq := NewPairing[string]()
+
+	pq.Enqueue("low", 3)
+	pq.Enqueue("high", 1)
+	pq.Enqueue("medium", 2)
+
+	if pq.Size() != 3 {
+		t.Errorf("Expected size 3, got %d", pq.Size())
+	}
+
+	for _, want := range []string{"high", "medium", "low"} {
+		value, ok := pq.Dequeue()
+		if !ok || value != want {
+			t.Errorf("Expected %q, got %v", want, value)
+		}
+	}
+
+	if _, ok := pq.Dequeue(); ok {
+		t.Error("Expected dequeue from empty pairing queue to return false")
+	}
+}
+
+func TestPairingUpdatePriorityAndRemove(t *testing.T) {
+	pq := NewPairing[string]()
+
+	item1 := pq.Enqueue("item1", 3)
+	item2 := pq.Enqueue("item2", 1)
+	pq.Enqueue("item3", 2)
+
+	pq.UpdatePriority(item1, 0)
+	value, ok := pq.Dequeue()
+	if !ok || value != "item1" {
+		t.Errorf("Expected 'item1' after priority update, got %v", value)
+	}
+
+	removed, ok := pq.Remove(item2)
+	if !ok || removed != "item2" {
+		t.Errorf("Expected to remove 'item2', got %v", removed)
+	}
+
+	value, ok = pq.Dequeue()
+	if !ok || value != "item3" {
+		t.Errorf("Expected 'item3', got %v", value)
+	}
+}
+
+func TestMeldPairing(t *testing.T) {
+	a := NewPairing[int]()
+	b := NewPairing[int]()
+
+	a.Enqueue(1, 5)
+	a.Enqueue(2, 1)
+	b.Enqueue(3, 3)
+	b.Enqueue(4, 2)
+
+	merged := a.Meld(b)
+
+	if !a.IsEmpty() || !b.IsEmpty() {
+		t.Error("Expected both source queues to be empty after Meld")
+	}
+	if merged.Size() != 4 {
+		t.Errorf("Expected merged size 4, got %d", merged.Size())
+	}
+
+	for _, want := range []int{2, 4, 3, 1} {
+		value, ok := merged.Dequeue()
+		if !ok || value != want {
+			t.Errorf("Expected %d, got %v", want, value)
+		}
+	}
+}
+
+func TestMeldConcurrentOppositeDirectionsDoesNotDeadlock(t *testing.T) {
+	// a.Meld(b) and b.Meld(a) running concurrently lock both mutexes in
+	// opposite orders unless Meld imposes a consistent global order;
+	// this reproduces that AB-BA pattern many times to catch a deadlock.
+	for i := 0; i < 200; i++ {
+		a := NewPairing[int]()
+		b := NewPairing[int]()
+		a.Enqueue(1, 1)
+		b.Enqueue(2, 2)
+
+		done := make(chan bool, 2)
+		go func() { a.Meld(b); done <- true }()
+		go func() { b.Meld(a); done <- true }()
+
+		for j := 0; j < 2; j++ {
+			select {
+			case <-done:
+			case <-time.After(2 * time.Second):
+				t.Fatalf("Meld deadlocked on iteration %d", i)
+			}
+		}
+	}
+}
+
+func TestMeldIntoBinaryFallback(t *testing.T) {
+	a := New[int]()
+	b := New[int]()
+
+	a.Enqueue(1, 2)
+	b.Enqueue(2, 1)
+
+	a.MeldInto(b)
+
+	if !b.IsEmpty() {
+		t.Error("Expected b to be empty after MeldInto")
+	}
+	if a.Size() != 2 {
+		t.Errorf("Expected size 2, got %d", a.Size())
+	}
+
+	value, ok := a.Dequeue()
+	if !ok || value != 2 {
+		t.Errorf("Expected 2, got %v", value)
+	}
+}
+
+func TestBoundedEvictsLowest(t *testing.T) {
+	pq := NewBounded[string](3, true)
+
+	pq.Enqueue("a", 5)
+	pq.Enqueue("b", 1)
+	pq.Enqueue("c", 3)
+
+	item, evicted := pq.EnqueueEvicting("d", 4)
+	if item == nil || item.Value != "d" {
+		t.Fatalf("Expected inserted item 'd', got %v", item)
+	}
+	if evicted == nil || evicted.Value != "b" {
+		t.Errorf("Expected eviction of lowest-priority item 'b', got %v", evicted)
+	}
+	if pq.Size() != 3 {
+		t.Errorf("Expected size to stay at capacity 3, got %d", pq.Size())
+	}
+
+	min, ok := pq.PeekMin()
+	if !ok || min != "c" {
+		t.Errorf("Expected min 'c', got %v", min)
+	}
+	max, ok := pq.PeekMax()
+	if !ok || max != "a" {
+		t.Errorf("Expected max 'a', got %v", max)
+	}
+}
+
+func TestBoundedEvictsHighest(t *testing.T) {
+	pq := NewBounded[int](2, false)
+
+	pq.Enqueue(1, 10)
+	pq.Enqueue(2, 20)
+	_, evicted := pq.EnqueueEvicting(3, 5)
+
+	if evicted == nil || evicted.Value != 2 {
+		t.Errorf("Expected eviction of highest-priority item, got %v", evicted)
+	}
+	if pq.Size() != 2 {
+		t.Errorf("Expected size 2, got %d", pq.Size())
+	}
+
+	value, ok := pq.PopMin()
+	if !ok || value != 3 {
+		t.Errorf("Expected PopMin to return 3, got %v", value)
+	}
+	value, ok = pq.PopMax()
+	if !ok || value != 1 {
+		t.Errorf("Expected PopMax to return 1, got %v", value)
+	}
+}
+
+// assertMinMaxHeapInvariant walks pq's min-max heap array and fails the
+// test if any node ranks (per pq.mmLess) on the wrong side of any of its
+// descendants for its level: a min-level node must be mmLess-or-equal to
+// every descendant, a max-level node must rank last against every
+// descendant.
+func assertMinMaxHeapInvariant[T any](t *testing.T, pq *PriorityQueue[T]) {
+	t.Helper()
+	n := len(pq.items)
+	var descendants func(i int) []int
+	descendants = func(i int) []int {
+		var out []int
+		for _, c := range []int{2*i + 1, 2*i + 2} {
+			if c < n {
+				out = append(out, c)
+				out = append(out, descendants(c)...)
+			}
+		}
+		return out
+	}
+	for i := 0; i < n; i++ {
+		for _, d := range descendants(i) {
+			if mmLevelIsMin(i) {
+				if pq.mmLess(pq.items[d], pq.items[i]) {
+					t.Fatalf("min-level invariant violated: items[%d]=%v should be <= descendant items[%d]=%v",
+						i, pq.items[i].Priority, d, pq.items[d].Priority)
+				}
+			} else if pq.mmLess(pq.items[i], pq.items[d]) {
+				t.Fatalf("max-level invariant violated: items[%d]=%v should be >= descendant items[%d]=%v",
+					i, pq.items[i].Priority, d, pq.items[d].Priority)
+			}
+		}
+	}
+}
+
+func TestBoundedUpdatePriorityRestoresInvariant(t *testing.T) {
+	// Regression test: UpdatePriority on a bounded queue used to leave the
+	// min-max heap corrupted whenever the changed item's fix-up crossed a
+	// grandparent boundary, because mmPushDownMax/Min reported the wrong
+	// index for where the item settled. Dropping a max-level item all the
+	// way from the top of its subtree to a new global minimum exercises
+	// exactly that path.
+	pq := NewBounded[string](15, true)
+	names := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l", "m", "n", "root"}
+	priorities := []int{10, 20, 30, 40, 50, 60, 70, 80, 90, 15, 25, 35, 45, 55, 99}
+	items := make(map[string]*Item[string], len(names))
+	for i, name := range names {
+		items[name] = pq.Enqueue(name, priorities[i])
+	}
+
+	pq.UpdatePriority(items["root"], 2)
+	assertMinMaxHeapInvariant(t, pq)
+
+	min, ok := pq.PeekMin()
+	if !ok || min != "root" {
+		t.Errorf("Expected PeekMin to return 'root' after the priority drop, got %v", min)
+	}
+}
+
+func TestBoundedUpdatePriorityAndRemoveMaintainInvariant(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	pq := NewBounded[int](0, true)
+	var live []*Item[int]
+
+	for step := 0; step < 500; step++ {
+		switch {
+		case len(live) < 3 || rng.Intn(3) == 0:
+			live = append(live, pq.Enqueue(step, rng.Intn(1000)))
+		case rng.Intn(2) == 0:
+			pq.UpdatePriority(live[rng.Intn(len(live))], rng.Intn(1000))
+		default:
+			idx := rng.Intn(len(live))
+			pq.Remove(live[idx])
+			live = append(live[:idx], live[idx+1:]...)
+		}
+		assertMinMaxHeapInvariant(t, pq)
+	}
+}
+
+func TestSetCapacityEvictsExcess(t *testing.T) {
+	pq := New[int]()
+	for i := 0; i < 5; i++ {
+		pq.Enqueue(i, i)
+	}
+
+	pq.SetCapacity(2)
+
+	if pq.Size() != 2 {
+		t.Errorf("Expected size 2 after SetCapacity, got %d", pq.Size())
+	}
+	min, ok := pq.PeekMin()
+	if !ok || min != 0 {
+		t.Errorf("Expected min 0 to survive, got %v", min)
+	}
+}
+
+func TestSetCapacityPreservesComparator(t *testing.T) {
+	lengthComparator := func(a, b string) int { return len(a) - len(b) }
+	pq := NewWithComparator(lengthComparator, false) // min-heap by string length
+
+	pq.Enqueue("hello", 0)   // length 5
+	pq.Enqueue("hi", 0)      // length 2
+	pq.Enqueue("goodbye", 0) // length 7
+
+	pq.SetCapacity(2)
+
+	if pq.Size() != 2 {
+		t.Fatalf("Expected size 2 after SetCapacity, got %d", pq.Size())
+	}
+	// A raw-Priority comparison would treat all three as tied (Priority
+	// 0) and could evict by insertion order instead of by the
+	// comparator's length ordering.
+	value, ok := pq.PopMin()
+	if !ok || value != "hi" {
+		t.Errorf("Expected shortest survivor 'hi', got %v", value)
+	}
+	value, ok = pq.PopMax()
+	if !ok || value != "hello" {
+		t.Errorf("Expected longest survivor 'hello', got %v", value)
+	}
+}
+
+func TestSetCapacityPreservesStableTieBreak(t *testing.T) {
+	pq := NewStable[string]()
+	pq.Enqueue("first", 1)
+	pq.Enqueue("second", 1)
+	pq.Enqueue("third", 1)
+
+	pq.SetCapacity(10) // still under capacity, just converts to bounded
+
+	for _, want := range []string{"first", "second", "third"} {
+		value, ok := pq.Dequeue()
+		if !ok || value != want {
+			t.Errorf("Expected %q in FIFO order, got %v", want, value)
+		}
+	}
+}
+
+func TestStableFIFOTieBreak(t *testing.T) {
+	pq := NewStable[string]()
+
+	pq.Enqueue("first", 1)
+	pq.Enqueue("second", 1)
+	pq.Enqueue("third", 1)
+	pq.Enqueue("urgent", 0)
+
+	for _, want := range []string{"urgent", "first", "second", "third"} {
+		value, ok := pq.Dequeue()
+		if !ok || value != want {
+			t.Errorf("Expected %q, got %v", want, value)
+		}
+	}
+}
+
+func TestUnstableDoesNotGuaranteeFIFO(t *testing.T) {
+	// Without NewStable, equal-priority items have no ordering guarantee;
+	// this just documents that NewStable is opt-in.
+	pq := New[string]()
+	pq.Enqueue("a", 1)
+	pq.Enqueue("b", 1)
+	if pq.Size() != 2 {
+		t.Errorf("Expected size 2, got %d", pq.Size())
+	}
+}
+
 func ExamplePriorityQueue_maxHeap() {
 	// Create a max-heap priority queue
 	pq := NewMax[int]()
@@ -0,0 +1,152 @@
+package prioqueue
+
+import (
+	"bytes"
+	"container/heap"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// pqWireItem is the serialized form of a single Item[T].
+type pqWireItem[T any] struct {
+	Value    T   `json:"value"`
+	Priority int `json:"priority"`
+}
+
+// pqWireFormat is the serialized form of a PriorityQueue[T], used by both
+// MarshalJSON/UnmarshalJSON and GobEncode/GobDecode. HasComparator is
+// informational only: a Comparator[T] is a function value and can't be
+// serialized, so it is never restored automatically. If it's true after
+// decoding, call SetComparator with the same comparator used before
+// encoding. IsStable, unlike HasComparator, is fully restorable on its
+// own: loadWireFormat re-enables FIFO tie-breaking and reassigns each
+// item a fresh seq in wire order, so ties among restored items keep some
+// consistent order and any items enqueued afterward sort behind all of
+// them.
+type pqWireFormat[T any] struct {
+	IsMaxHeap     bool            `json:"is_max_heap"`
+	HasComparator bool            `json:"has_comparator"`
+	IsStable      bool            `json:"is_stable"`
+	Items         []pqWireItem[T] `json:"items"`
+}
+
+// toWireFormat snapshots pq's items regardless of its internal backing
+// (binary heap or pairing heap). Callers must hold at least pq.mutex.RLock.
+func (pq *PriorityQueue[T]) toWireFormat() pqWireFormat[T] {
+	items := pq.items
+	if pq.pairing {
+		items = pq.pairingCollect()
+	}
+
+	wire := pqWireFormat[T]{
+		IsMaxHeap:     pq.isMaxHeap,
+		HasComparator: pq.comparator != nil,
+		IsStable:      pq.stable,
+		Items:         make([]pqWireItem[T], len(items)),
+	}
+	for i, item := range items {
+		wire.Items[i] = pqWireItem[T]{Value: item.Value, Priority: item.Priority}
+	}
+	return wire
+}
+
+// loadWireFormat rebuilds pq as a binary-heap-backed queue from wire,
+// using heap.Init so restore is O(n) rather than O(n log n) from
+// repeated Enqueue. Callers must hold pq.mutex.Lock; the comparator (if
+// any) must be re-supplied separately via SetComparator.
+func (pq *PriorityQueue[T]) loadWireFormat(wire pqWireFormat[T]) {
+	pq.isMaxHeap = wire.IsMaxHeap
+	pq.stable = wire.IsStable
+	pq.pairing = false
+	pq.bounded = false
+	pq.root = nil
+	pq.count = 0
+
+	pq.items = make([]*Item[T], len(wire.Items))
+	for i, wi := range wire.Items {
+		item := &Item[T]{Value: wi.Value, Priority: wi.Priority}
+		if pq.stable {
+			item.seq = uint64(i)
+		}
+		pq.items[i] = item
+	}
+	pq.seqCounter = uint64(len(wire.Items))
+	heap.Init(pq)
+}
+
+// SetComparator installs comparator on a queue that was just restored via
+// UnmarshalJSON/GobDecode (or built directly) and re-establishes the heap
+// invariant under it. Comparator[T] can't be serialized, so a queue
+// decoded with HasComparator true needs this call before it's safe to use.
+func (pq *PriorityQueue[T]) SetComparator(comparator Comparator[T]) {
+	pq.mutex.Lock()
+	defer pq.mutex.Unlock()
+
+	pq.comparator = comparator
+	switch {
+	case pq.pairing:
+		items := pq.pairingCollect()
+		pq.root = nil
+		for _, item := range items {
+			pq.root = pq.pairingInsert(pq.root, item)
+		}
+	case pq.bounded:
+		pq.mmBuildHeap()
+	default:
+		heap.Init(pq)
+	}
+}
+
+// MarshalJSON implements json.Marshaler. The comparator, if any, is not
+// serialized; see pqWireFormat.
+func (pq *PriorityQueue[T]) MarshalJSON() ([]byte, error) {
+	pq.mutex.RLock()
+	defer pq.mutex.RUnlock()
+
+	return json.Marshal(pq.toWireFormat())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It always restores a
+// binary-heap-backed queue; pairing/bounded backing is not preserved.
+// If the original queue used a custom comparator, call SetComparator
+// after unmarshaling.
+func (pq *PriorityQueue[T]) UnmarshalJSON(data []byte) error {
+	var wire pqWireFormat[T]
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+
+	pq.mutex.Lock()
+	defer pq.mutex.Unlock()
+	pq.loadWireFormat(wire)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder. The comparator, if any, is not
+// serialized; see pqWireFormat.
+func (pq *PriorityQueue[T]) GobEncode() ([]byte, error) {
+	pq.mutex.RLock()
+	defer pq.mutex.RUnlock()
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(pq.toWireFormat()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder. It always restores a
+// binary-heap-backed queue; pairing/bounded backing is not preserved.
+// If the original queue used a custom comparator, call SetComparator
+// after decoding.
+func (pq *PriorityQueue[T]) GobDecode(data []byte) error {
+	var wire pqWireFormat[T]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&wire); err != nil {
+		return err
+	}
+
+	pq.mutex.Lock()
+	defer pq.mutex.Unlock()
+	pq.loadWireFormat(wire)
+	return nil
+}
@@ -0,0 +1,181 @@
+package prioqueue
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+)
+
+type serializablePerson struct {
+	Name string
+	Age  int
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	pq := NewMax[int]()
+	pq.Enqueue(1, 10)
+	pq.Enqueue(2, 30)
+	pq.Enqueue(3, 20)
+
+	data, err := json.Marshal(pq)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	restored := New[int]()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if restored.Size() != 3 {
+		t.Fatalf("Expected size 3, got %d", restored.Size())
+	}
+	if !restored.isMaxHeap {
+		t.Error("Expected restored queue to preserve max-heap orientation")
+	}
+
+	for _, want := range []int{2, 3, 1} {
+		value, ok := restored.Dequeue()
+		if !ok || value != want {
+			t.Errorf("Expected %d, got %v", want, value)
+		}
+	}
+}
+
+func TestJSONRoundTripStructValues(t *testing.T) {
+	pq := New[serializablePerson]()
+	pq.Enqueue(serializablePerson{Name: "Bob", Age: 25}, 2)
+	pq.Enqueue(serializablePerson{Name: "Alice", Age: 30}, 1)
+
+	data, err := json.Marshal(pq)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	restored := New[serializablePerson]()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	person, ok := restored.Dequeue()
+	if !ok || person.Name != "Alice" {
+		t.Errorf("Expected Alice, got %v", person)
+	}
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	pq := New[string]()
+	pq.Enqueue("low", 3)
+	pq.Enqueue("high", 1)
+	pq.Enqueue("medium", 2)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(pq); err != nil {
+		t.Fatalf("gob encode failed: %v", err)
+	}
+
+	restored := New[string]()
+	if err := gob.NewDecoder(&buf).Decode(restored); err != nil {
+		t.Fatalf("gob decode failed: %v", err)
+	}
+
+	for _, want := range []string{"high", "medium", "low"} {
+		value, ok := restored.Dequeue()
+		if !ok || value != want {
+			t.Errorf("Expected %q, got %v", want, value)
+		}
+	}
+}
+
+func TestJSONRoundTripPreservesStable(t *testing.T) {
+	pq := NewStable[string]()
+	pq.Enqueue("first", 1)
+	pq.Enqueue("second", 1)
+	pq.Enqueue("third", 1)
+
+	data, err := json.Marshal(pq)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	restored := New[string]()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if !restored.stable {
+		t.Error("Expected restored queue to preserve stable tie-breaking")
+	}
+
+	// A restored queue reassigns seq in wire order rather than original
+	// enqueue order, but that order is still self-consistent, and a fresh
+	// enqueue must sort behind everything that existed at encode time.
+	restored.Enqueue("fourth", 1)
+	value, ok := restored.Dequeue()
+	if !ok {
+		t.Fatal("expected an item")
+	}
+	if value == "fourth" {
+		t.Error("Expected an item enqueued before encoding to dequeue ahead of one enqueued after decoding")
+	}
+}
+
+func TestGobRoundTripPreservesStable(t *testing.T) {
+	pq := NewStable[string]()
+	pq.Enqueue("first", 1)
+	pq.Enqueue("second", 1)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(pq); err != nil {
+		t.Fatalf("gob encode failed: %v", err)
+	}
+
+	restored := New[string]()
+	if err := gob.NewDecoder(&buf).Decode(restored); err != nil {
+		t.Fatalf("gob decode failed: %v", err)
+	}
+
+	if !restored.stable {
+		t.Error("Expected restored queue to preserve stable tie-breaking")
+	}
+}
+
+func TestUnmarshalWithComparatorNeedsResupply(t *testing.T) {
+	lengthComparator := func(a, b string) int {
+		if len(a) < len(b) {
+			return -1
+		} else if len(a) > len(b) {
+			return 1
+		}
+		return 0
+	}
+
+	pq := NewWithComparator(lengthComparator, false)
+	pq.Enqueue("hello", 0)
+	pq.Enqueue("hi", 0)
+
+	data, err := json.Marshal(pq)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var wire pqWireFormat[string]
+	if err := json.Unmarshal(data, &wire); err != nil {
+		t.Fatalf("failed to inspect wire format: %v", err)
+	}
+	if !wire.HasComparator {
+		t.Error("Expected HasComparator to be true")
+	}
+
+	restored := New[string]()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	restored.SetComparator(lengthComparator)
+
+	value, ok := restored.Dequeue()
+	if !ok || value != "hi" {
+		t.Errorf("Expected 'hi' once comparator is re-supplied, got %v", value)
+	}
+}